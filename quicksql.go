@@ -1,20 +1,25 @@
 package quicksql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"strconv"
-	"strings"
+	"time"
 )
 
+const timeLayout = "2006-01-02 15:04:05"
+
 var (
-	ErrNullValue         = errors.New("quicksql: null value encountered")
-	ErrInvalidColumn     = errors.New("quicksql: invalid column")
-	ErrUnsupportedValue  = errors.New("quicksql: unsupported value for casting")
-	ErrPrimaryKeyNotSet  = errors.New("quicksql: primary key not set")
-	ErrPrimaryKeyInvalid = errors.New("quicksql: invalid primary key")
-	ErrTableNotSet       = errors.New("quicksql: table not set")
+	ErrNullValue          = errors.New("quicksql: null value encountered")
+	ErrInvalidColumn      = errors.New("quicksql: invalid column")
+	ErrUnsupportedValue   = errors.New("quicksql: unsupported value for casting")
+	ErrPrimaryKeyNotSet   = errors.New("quicksql: primary key not set")
+	ErrPrimaryKeyInvalid  = errors.New("quicksql: invalid primary key")
+	ErrTableNotSet        = errors.New("quicksql: table not set")
+	ErrInvalidDestination = errors.New("quicksql: dest must be a non-nil pointer to a struct or a slice of structs")
+	ErrTxNotSupported     = errors.New("quicksql: db does not support transactions")
 )
 
 type sessionContext struct {
@@ -27,6 +32,15 @@ type sessionContext struct {
 	// flag indicating whether the table we're working with
 	// has an auto incrementing PK
 	autoIncrement bool
+	// SQL dialect to generate statements for, when set at the session level
+	dialect Dialect
+	// {{IN:col}} sentinel expansions registered via InOption, in the order
+	// they were added
+	inClauses []inClause
+	// cache backend to install on the session, set via CacheBackendOption
+	cacheBackend Cache
+	// TTL to cache a single Select call's results under, set via CacheOption
+	cacheTTL time.Duration
 }
 
 type SessionOption func(ctx *sessionContext) error
@@ -59,158 +73,81 @@ func TableOption(name string) SessionOption {
 	}
 }
 
+// DialectOption sets the SQL dialect NewSession uses to generate Create,
+// Save, and Delete statements. Defaults to MySQLDialect{} when omitted.
+func DialectOption(dialect Dialect) SessionOption {
+	return func(ctx *sessionContext) error {
+		ctx.dialect = dialect
+		return nil
+	}
+}
+
+// CacheBackendOption installs cache on the session so that Select calls
+// made with CacheOption can be served from it.
+func CacheBackendOption(cache Cache) SessionOption {
+	return func(ctx *sessionContext) error {
+		ctx.cacheBackend = cache
+		return nil
+	}
+}
+
+// CacheOption caches a single Select call's results under ttl, keyed by
+// the query and its arguments. It's a no-op unless the session was built
+// with CacheBackendOption.
+func CacheOption(ttl time.Duration) SessionOption {
+	return func(ctx *sessionContext) error {
+		ctx.cacheTTL = ttl
+		return nil
+	}
+}
+
 type SqlInterface interface {
 	Query(string, ...interface{}) (*sql.Rows, error)
 	Exec(string, ...interface{}) (sql.Result, error)
 }
 
 type Session struct {
-	db SqlInterface
-}
-
-func NewSession(db SqlInterface) *Session {
-	return &Session{
-		db: db,
-	}
+	db      SqlInterface
+	dialect Dialect
+	cache   Cache
 }
 
-func (s *Session) Select(query string, options ...SessionOption) ([]*Record, error) {
-	selectCtx := &sessionContext{
-		args: []interface{}{},
-		pk:   []string{},
-	}
+func NewSession(db SqlInterface, options ...SessionOption) *Session {
+	sessCtx := &sessionContext{}
 
 	for _, option := range options {
-		if err := option(selectCtx); err != nil {
-			return nil, err
+		if err := option(sessCtx); err != nil {
+			// TODO not a big fan of this, but let's assume people are not doing silly things.
+			panic(err)
 		}
 	}
 
-	rows, err := s.db.Query(query, selectCtx.args...)
-	if err != nil {
-		return nil, err
+	dialect := sessCtx.dialect
+	if dialect == nil {
+		dialect = MySQLDialect{}
 	}
-	defer rows.Close()
 
-	colNames, err := rows.Columns()
-	if err != nil {
-		return nil, err
+	return &Session{
+		db:      db,
+		dialect: dialect,
+		cache:   sessCtx.cacheBackend,
 	}
+}
 
-	records := []*Record{}
-
-	for rows.Next() {
-		cols := make([]interface{}, len(colNames))
-		colPtrs := make([]interface{}, len(colNames))
-		for i := 0; i < len(colNames); i++ {
-			colPtrs[i] = &cols[i]
-		}
-
-		if err := rows.Scan(colPtrs...); err != nil {
-			return nil, err
-		}
-
-		record := NewRecord(TableOption(selectCtx.tableName), PrimaryKeyOption(selectCtx.pk...))
-		for i, col := range cols {
-			record.Set(colNames[i], col)
-		}
-
-		records = append(records, record)
-
-	}
-	return records, nil
+func (s *Session) Select(query string, options ...SessionOption) ([]*Record, error) {
+	return s.SelectContext(context.Background(), query, options...)
 }
 
 func (s *Session) Create(record *Record) error {
-	if record.tableName == "" {
-		return ErrTableNotSet
-	}
-
-	fields := []string{}
-	args := []interface{}{}
-	for field, value := range record.values {
-		fields = append(fields, "`"+field+"`")
-		args = append(args, value)
-	}
-
-	argPlaceholders := make([]string, len(args))
-	for i := range argPlaceholders {
-		argPlaceholders[i] = "?"
-	}
-
-	query := "INSERT INTO " + record.tableName + " (" + strings.Join(fields, ", ") + ") VALUES(" + strings.Join(argPlaceholders, ", ") + ")"
-
-	res, err := s.db.Exec(query, args...)
-	if err != nil {
-		return err
-	}
-
-	if len(record.pk) == 1 && record.autoIncrement {
-		// When a non-composite primary key is set and the value for the PK was not set
-		// as part of the create operation, then assume that we're working with auto incrementing table
-		// and try to read the last insert id into PK field.
-		lastid, err := res.LastInsertId()
-		if err == nil {
-			record.Set(record.pk[0], lastid)
-		} else {
-			// TODO we're silently skipping here, we might want to do something about it in the future.
-		}
-	}
-	return nil
+	return s.CreateContext(context.Background(), record)
 }
 
 func (s *Session) Save(record *Record) error {
-	args := []interface{}{}
-	pkFields := []string{}
-	fields := []string{}
-
-	if err := validateRecordForUpdateOrDelete(record); err != nil {
-		return err
-	}
-
-	for _, field := range record.pk {
-		pkFields = append(pkFields, "`"+field+"` = ?")
-	}
-
-	for field, value := range record.values {
-		fields = append(fields, "`"+field+"` = ?")
-		args = append(args, value)
-	}
-
-	for _, pkField := range record.pk {
-		pkValue, ok := record.values[pkField]
-		if !ok {
-			return ErrPrimaryKeyInvalid
-		}
-		args = append(args, pkValue)
-	}
-
-	query := "UPDATE " + record.tableName + " SET " + strings.Join(fields, ", ") + " WHERE " + strings.Join(pkFields, " AND ") + " LIMIT 1"
-
-	_, err := s.db.Exec(query, args...)
-	return err
+	return s.SaveContext(context.Background(), record)
 }
 
 func (s *Session) Delete(record *Record) error {
-	if err := validateRecordForUpdateOrDelete(record); err != nil {
-		return err
-	}
-
-	args := []interface{}{}
-	pkFields := []string{}
-
-	for _, field := range record.pk {
-		pkFields = append(pkFields, "`"+field+"` = ?")
-		pkValue, ok := record.values[field]
-		if !ok {
-			return ErrPrimaryKeyInvalid
-		}
-		args = append(args, pkValue)
-	}
-
-	query := "DELETE FROM " + record.tableName + " WHERE " + strings.Join(pkFields, " AND ") + " LIMIT 1"
-	_, err := s.db.Exec(query, args...)
-	return err
+	return s.DeleteContext(context.Background(), record)
 }
 
 type Record struct {
@@ -268,6 +205,9 @@ func (r *Record) Set(name string, value interface{}) error {
 	case nil:
 		r.values[name] = nil
 		return nil
+	case time.Time:
+		r.values[name] = []uint8(v.Format(timeLayout))
+		return nil
 	}
 
 	byteSlice := []uint8(fmt.Sprintf("%v", value))
@@ -346,6 +286,122 @@ func (r *Record) MustInt64(name string) int64 {
 	return v
 }
 
+func (r *Record) Float64(name string) (float64, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return 0, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return 0, ErrNullValue
+	}
+
+	return strconv.ParseFloat(string(v), 64)
+}
+
+func (r *Record) MustFloat64(name string) float64 {
+	v, err := r.Float64(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (r *Record) Bool(name string) (bool, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return false, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return false, ErrNullValue
+	}
+
+	return strconv.ParseBool(string(v))
+}
+
+func (r *Record) MustBool(name string) bool {
+	v, err := r.Bool(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Bytes returns the raw column value without copying it into a string,
+// which matters for BINARY/BLOB columns.
+func (r *Record) Bytes(name string) ([]byte, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return nil, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return nil, ErrNullValue
+	}
+
+	return v, nil
+}
+
+func (r *Record) MustBytes(name string) []byte {
+	v, err := r.Bytes(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NullString is the NULL-safe equivalent of String: instead of returning
+// ErrNullValue, it reports the NULL-ness of the column via isNull.
+func (r *Record) NullString(name string) (value string, isNull bool, err error) {
+	v, ok := r.values[name]
+	if !ok {
+		return "", false, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return "", true, nil
+	}
+
+	return string(v), false, nil
+}
+
+// NullInt64 is the NULL-safe equivalent of Int64.
+func (r *Record) NullInt64(name string) (value int64, isNull bool, err error) {
+	v, ok := r.values[name]
+	if !ok {
+		return 0, false, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return 0, true, nil
+	}
+
+	number, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return number, false, nil
+}
+
+// NullTime is the NULL-safe equivalent of Time.
+func (r *Record) NullTime(name string) (value time.Time, isNull bool, err error) {
+	v, ok := r.values[name]
+	if !ok {
+		return time.Time{}, false, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return time.Time{}, true, nil
+	}
+
+	t, err := time.Parse(timeLayout, string(v))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, false, nil
+}
+
 func validateRecordForUpdateOrDelete(record *Record) error {
 	if record.tableName == "" {
 		return ErrTableNotSet
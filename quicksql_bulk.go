@@ -0,0 +1,167 @@
+package quicksql
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// inClause is a registered InOption expansion: the {{IN:col}} sentinel
+// named col should be replaced with one placeholder per value in vals.
+type inClause struct {
+	col  string
+	vals []interface{}
+}
+
+// InOption rewrites a `{{IN:col}}` sentinel in the caller's query into a
+// placeholder list (`?, ?, ...`) matching vals, and appends vals to the
+// query arguments. It lets callers build `WHERE x IN (...)` queries
+// without manually joining placeholders:
+//
+//	session.Select("SELECT * FROM t WHERE status = ? AND id IN ({{IN:id}})", ArgsOption("active"), InOption("id", 1, 2, 3))
+//
+// When a query mixes InOption with ArgsOption or other literal `?`
+// placeholders, the literal placeholders must come before the `{{IN:...}}`
+// sentinels in the query text, since InOption's values are appended after
+// the other args.
+func InOption(col string, vals ...interface{}) SessionOption {
+	return func(ctx *sessionContext) error {
+		ctx.inClauses = append(ctx.inClauses, inClause{col: col, vals: vals})
+		return nil
+	}
+}
+
+// expandInClauses replaces each clause's {{IN:col}} sentinel with one
+// dialect placeholder per value, numbered starting after argOffset
+// preceding arguments (so the numbering lines up with dialects like
+// Postgres, where placeholders are positional $N values rather than bare
+// `?`s).
+func expandInClauses(dialect Dialect, query string, clauses []inClause, argOffset int) (string, []interface{}) {
+	args := []interface{}{}
+	next := argOffset + 1
+	for _, clause := range clauses {
+		placeholders := make([]string, len(clause.vals))
+		for i := range placeholders {
+			placeholders[i] = dialect.Placeholder(next)
+			next++
+		}
+		query = strings.Replace(query, "{{IN:"+clause.col+"}}", strings.Join(placeholders, ", "), 1)
+		args = append(args, clause.vals...)
+	}
+	return query, args
+}
+
+// SelectByIDs hydrates the rows of table whose pk column matches one of
+// ids, mirroring the "collect IDs, then hydrate in bulk" pattern: run a
+// cheap query to gather the IDs you care about, then fetch them all in a
+// single round trip instead of one query per ID.
+func (s *Session) SelectByIDs(table string, pk string, ids []interface{}, options ...SessionOption) ([]*Record, error) {
+	if len(ids) == 0 {
+		return []*Record{}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	for i := range placeholders {
+		placeholders[i] = s.dialect.Placeholder(i + 1)
+	}
+
+	query := "SELECT * FROM " + table + " WHERE " + s.dialect.QuoteIdent(pk) + " IN (" + strings.Join(placeholders, ", ") + ")"
+
+	options = append([]SessionOption{ArgsOption(ids...), TableOption(table), PrimaryKeyOption(pk)}, options...)
+	return s.Select(query, options...)
+}
+
+// CreateMany inserts records in a single round trip per table/column-set
+// combination, emitting one `INSERT INTO t (cols...) VALUES (...),(...)`
+// statement instead of one INSERT per record. For tables with a
+// non-composite auto-increment PK, the LastInsertId of the batch is
+// distributed across the records under the assumption - true for MySQL -
+// that a single bulk insert gets contiguous IDs.
+func (s *Session) CreateMany(records []*Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	type group struct {
+		tableName     string
+		fields        []string
+		pk            []string
+		autoIncrement bool
+		records       []*Record
+	}
+
+	groups := []*group{}
+	index := map[string]*group{}
+
+	for _, record := range records {
+		if record.tableName == "" {
+			return ErrTableNotSet
+		}
+
+		fields := record.Fields()
+		sort.Strings(fields)
+		key := record.tableName + "\x00" + strings.Join(fields, "\x00")
+
+		g, ok := index[key]
+		if !ok {
+			g = &group{
+				tableName:     record.tableName,
+				fields:        fields,
+				pk:            record.pk,
+				autoIncrement: record.autoIncrement,
+			}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.records = append(g.records, record)
+	}
+
+	for _, g := range groups {
+		if err := s.createMany(g.tableName, g.fields, g.pk, g.autoIncrement, g.records); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Session) createMany(tableName string, fields []string, pk []string, autoIncrement bool, records []*Record) error {
+	quotedFields := make([]string, len(fields))
+	for i, field := range fields {
+		quotedFields[i] = s.dialect.QuoteIdent(field)
+	}
+
+	args := []interface{}{}
+	valueGroups := make([]string, len(records))
+	placeholder := 1
+	for i, record := range records {
+		rowPlaceholders := make([]string, len(fields))
+		for j, field := range fields {
+			rowPlaceholders[j] = s.dialect.Placeholder(placeholder)
+			placeholder++
+			args = append(args, record.values[field])
+		}
+		valueGroups[i] = "(" + strings.Join(rowPlaceholders, ", ") + ")"
+	}
+
+	query := "INSERT INTO " + tableName + " (" + strings.Join(quotedFields, ", ") + ") VALUES " + strings.Join(valueGroups, ", ")
+
+	res, err := s.execContext(context.Background(), query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(pk) == 1 && autoIncrement {
+		firstID, err := res.LastInsertId()
+		if err == nil {
+			for i, record := range records {
+				record.Set(pk[0], firstID+int64(i))
+			}
+		} else {
+			// TODO we're silently skipping here, we might want to do something about it in the future.
+		}
+	}
+
+	s.invalidateTable(tableName)
+	return nil
+}
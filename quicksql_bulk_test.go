@@ -0,0 +1,84 @@
+package quicksql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandInClauses(t *testing.T) {
+	query, args := expandInClauses(
+		MySQLDialect{},
+		"SELECT * FROM t WHERE id IN ({{IN:id}})",
+		[]inClause{{col: "id", vals: []interface{}{1, 2, 3}}},
+		0,
+	)
+	assert.Equal(t, "SELECT * FROM t WHERE id IN (?, ?, ?)", query)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func TestExpandInClausesPostgresOffset(t *testing.T) {
+	query, args := expandInClauses(
+		PostgresDialect{},
+		"SELECT * FROM t WHERE status = $1 AND id IN ({{IN:id}})",
+		[]inClause{{col: "id", vals: []interface{}{1, 2, 3}}},
+		1,
+	)
+	assert.Equal(t, "SELECT * FROM t WHERE status = $1 AND id IN ($2, $3, $4)", query)
+	assert.Equal(t, []interface{}{1, 2, 3}, args)
+}
+
+func newBulkRecord(id int) *Record {
+	record := NewRecord(TableOption("test_table"), PrimaryKeyOption("id"), AutoIncrementOption())
+	record.Set("field_string", "field_string")
+	record.Set("field_integer", id)
+	record.Set("field_binary", "binary")
+	record.Set("field_datetime", "2020-01-01")
+	record.Set("field_text", "text")
+	record.Set("field_decimal", 555.66)
+	return record
+}
+
+func TestCreateMany(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	records := []*Record{newBulkRecord(1), newBulkRecord(2), newBulkRecord(3)}
+	assert.NoError(t, session.CreateMany(records))
+
+	ids := make([]interface{}, len(records))
+	for i, record := range records {
+		ids[i] = record.MustInt64("id")
+	}
+
+	rows, err := session.SelectByIDs("test_table", "id", ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(rows))
+}
+
+func TestSelectByIDsEmpty(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	rows, err := session.SelectByIDs("test_table", "id", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(rows))
+}
+
+func TestSelectWithInOption(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	rows, err := session.Select(
+		"SELECT * FROM test_table WHERE id IN ({{IN:id}})",
+		InOption("id", 1),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+}
@@ -0,0 +1,140 @@
+package quicksql
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Cache is a pluggable row cache for Select. Implementations are expected
+// to be safe for concurrent use; quicksql ships LRUCache, an in-memory
+// implementation, but users can plug in Redis, memcached, etc.
+type Cache interface {
+	// Get returns the cached records for key, if present and not expired.
+	Get(key string) ([]*Record, bool)
+	// Set stores recs under key for ttl.
+	Set(key string, recs []*Record, ttl time.Duration)
+	// InvalidateTable drops cached entries touched by writes to table.
+	InvalidateTable(table string)
+	// InvalidateRow drops cached entries touched by writes to the row
+	// identified by pk in table.
+	InvalidateRow(table string, pk map[string]interface{})
+}
+
+// cacheKey derives a Cache key from a query, its bind arguments, and the
+// table/pk the caller tagged the call with. Folding table/pk into the key
+// keeps two call sites that happen to issue identical SQL but hydrate
+// Records under different TableOption/PrimaryKeyOption settings (e.g. one
+// read-only report query and another meant for Save/Delete) from
+// cross-contaminating each other's cache entry.
+func cacheKey(query string, args []interface{}, table string, pk []string) string {
+	h := sha256.New()
+	h.Write([]byte(query))
+	for _, arg := range args {
+		fmt.Fprintf(h, "|%v", arg)
+	}
+	fmt.Fprintf(h, "|%s", table)
+	for _, field := range pk {
+		fmt.Fprintf(h, "|%s", field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type lruEntry struct {
+	key       string
+	records   []*Record
+	expiresAt time.Time
+}
+
+// LRUCache is a fixed-capacity, in-memory Cache implementation safe for
+// concurrent use. Entries past their TTL are evicted lazily on Get.
+//
+// Because Cache's Set doesn't carry the table(s) a query touched,
+// LRUCache can't selectively invalidate entries for a single table or
+// row: InvalidateTable and InvalidateRow both conservatively clear the
+// entire cache.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache creates an LRUCache holding at most capacity entries. A
+// capacity of zero or less disables eviction, so the cache is unbounded.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]*Record, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.ll.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return entry.records, true
+}
+
+func (c *LRUCache) Set(key string, recs []*Record, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry).records = recs
+		elem.Value.(*lruEntry).expiresAt = time.Now().Add(ttl)
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&lruEntry{
+		key:       key,
+		records:   recs,
+		expiresAt: time.Now().Add(ttl),
+	})
+	c.items[key] = elem
+
+	if c.capacity > 0 {
+		for c.ll.Len() > c.capacity {
+			oldest := c.ll.Back()
+			if oldest == nil {
+				break
+			}
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
+
+func (c *LRUCache) InvalidateTable(table string) {
+	c.clear()
+}
+
+func (c *LRUCache) InvalidateRow(table string, pk map[string]interface{}) {
+	c.clear()
+}
+
+func (c *LRUCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll.Init()
+	c.items = map[string]*list.Element{}
+}
@@ -0,0 +1,101 @@
+package quicksql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheGetSetExpiry(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	recs := []*Record{NewRecord()}
+	cache.Set("a", recs, time.Hour)
+
+	got, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, recs, got)
+
+	cache.Set("b", recs, -time.Second)
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+}
+
+func TestLRUCacheEvictsOldest(t *testing.T) {
+	cache := NewLRUCache(1)
+
+	cache.Set("a", []*Record{NewRecord()}, time.Hour)
+	cache.Set("b", []*Record{NewRecord()}, time.Hour)
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	_, ok = cache.Get("b")
+	assert.True(t, ok)
+}
+
+func TestLRUCacheInvalidation(t *testing.T) {
+	cache := NewLRUCache(10)
+	cache.Set("a", []*Record{NewRecord()}, time.Hour)
+
+	cache.InvalidateTable("test_table")
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+}
+
+func TestCacheKeyDiffersByTableAndPK(t *testing.T) {
+	same := cacheKey("SELECT * FROM t", []interface{}{1}, "t", []string{"id"})
+	assert.Equal(t, same, cacheKey("SELECT * FROM t", []interface{}{1}, "t", []string{"id"}))
+
+	differentTable := cacheKey("SELECT * FROM t", []interface{}{1}, "other_table", []string{"id"})
+	assert.NotEqual(t, same, differentTable)
+
+	differentPK := cacheKey("SELECT * FROM t", []interface{}{1}, "t", []string{"other_id"})
+	assert.NotEqual(t, same, differentPK)
+
+	noTable := cacheKey("SELECT * FROM t", []interface{}{1}, "", nil)
+	assert.NotEqual(t, same, noTable)
+}
+
+func TestSelectWithCacheOption(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	cache := NewLRUCache(100)
+	session := NewSession(db, CacheBackendOption(cache))
+
+	rows, err := session.Select(
+		"SELECT * FROM test_table",
+		CacheOption(time.Minute),
+		TableOption("test_table"),
+		PrimaryKeyOption("id"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rows))
+
+	// A second call with the same query is served from the cache.
+	cached, err := session.Select(
+		"SELECT * FROM test_table",
+		CacheOption(time.Minute),
+		TableOption("test_table"),
+		PrimaryKeyOption("id"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(cached))
+
+	assert.NoError(t, session.Delete(rows[0]))
+
+	// LRUCache.InvalidateRow clears the whole cache, so the row deleted
+	// above doesn't come back even though the same cached query is run
+	// again.
+	afterDelete, err := session.Select(
+		"SELECT * FROM test_table",
+		CacheOption(time.Minute),
+		TableOption("test_table"),
+		PrimaryKeyOption("id"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(afterDelete))
+}
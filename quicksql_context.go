@@ -0,0 +1,256 @@
+package quicksql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// queryContexter is satisfied by drivers (such as *sql.DB and *sql.Tx) that
+// support context-aware queries. Session falls back to the plain Query
+// method when db doesn't implement it, so SqlInterface stays unchanged and
+// backward compatible.
+type queryContexter interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// execContexter is the Exec equivalent of queryContexter.
+type execContexter interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (s *Session) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if qc, ok := s.db.(queryContexter); ok {
+		return qc.QueryContext(ctx, query, args...)
+	}
+	return s.db.Query(query, args...)
+}
+
+func (s *Session) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if ec, ok := s.db.(execContexter); ok {
+		return ec.ExecContext(ctx, query, args...)
+	}
+	return s.db.Exec(query, args...)
+}
+
+// SelectContext is the context-aware variant of Select. The context is
+// honored for cancellation and deadlines when db supports QueryContext,
+// which lets long-running queries be canceled from an HTTP handler or
+// similar caller.
+func (s *Session) SelectContext(ctx context.Context, query string, options ...SessionOption) ([]*Record, error) {
+	selectCtx := &sessionContext{
+		args: []interface{}{},
+		pk:   []string{},
+	}
+
+	for _, option := range options {
+		if err := option(selectCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	query, inArgs := expandInClauses(s.dialect, query, selectCtx.inClauses, len(selectCtx.args))
+	args := append(selectCtx.args, inArgs...)
+
+	var cacheKeyForCall string
+	if s.cache != nil && selectCtx.cacheTTL > 0 {
+		cacheKeyForCall = cacheKey(query, args, selectCtx.tableName, selectCtx.pk)
+		if records, ok := s.cache.Get(cacheKeyForCall); ok {
+			return records, nil
+		}
+	}
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	colNames, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*Record{}
+
+	for rows.Next() {
+		cols := make([]interface{}, len(colNames))
+		colPtrs := make([]interface{}, len(colNames))
+		for i := 0; i < len(colNames); i++ {
+			colPtrs[i] = &cols[i]
+		}
+
+		if err := rows.Scan(colPtrs...); err != nil {
+			return nil, err
+		}
+
+		record := NewRecord(TableOption(selectCtx.tableName), PrimaryKeyOption(selectCtx.pk...))
+		for i, col := range cols {
+			record.Set(colNames[i], col)
+		}
+
+		records = append(records, record)
+
+	}
+
+	if cacheKeyForCall != "" {
+		s.cache.Set(cacheKeyForCall, records, selectCtx.cacheTTL)
+	}
+
+	return records, nil
+}
+
+// CreateContext is the context-aware variant of Create.
+func (s *Session) CreateContext(ctx context.Context, record *Record) error {
+	if record.tableName == "" {
+		return ErrTableNotSet
+	}
+
+	fields := []string{}
+	args := []interface{}{}
+	for field, value := range record.values {
+		fields = append(fields, s.dialect.QuoteIdent(field))
+		args = append(args, value)
+	}
+
+	argPlaceholders := make([]string, len(args))
+	for i := range argPlaceholders {
+		argPlaceholders[i] = s.dialect.Placeholder(i + 1)
+	}
+
+	query := "INSERT INTO " + record.tableName + " (" + strings.Join(fields, ", ") + ") VALUES(" + strings.Join(argPlaceholders, ", ") + ")"
+
+	if len(record.pk) == 1 && record.autoIncrement {
+		if returningQuery, ok := s.dialect.InsertReturningPK(query, record.pk); ok {
+			if err := s.createReturningPK(ctx, returningQuery, args, record); err != nil {
+				return err
+			}
+			s.invalidateTable(record.tableName)
+			return nil
+		}
+	}
+
+	res, err := s.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(record.pk) == 1 && record.autoIncrement {
+		// When a non-composite primary key is set and the value for the PK was not set
+		// as part of the create operation, then assume that we're working with auto incrementing table
+		// and try to read the last insert id into PK field.
+		lastid, err := res.LastInsertId()
+		if err == nil {
+			record.Set(record.pk[0], lastid)
+		} else {
+			// TODO we're silently skipping here, we might want to do something about it in the future.
+		}
+	}
+
+	s.invalidateTable(record.tableName)
+	return nil
+}
+
+func (s *Session) invalidateTable(table string) {
+	if s.cache != nil {
+		s.cache.InvalidateTable(table)
+	}
+}
+
+func (s *Session) invalidateRow(record *Record) {
+	if s.cache == nil {
+		return
+	}
+
+	pk := make(map[string]interface{}, len(record.pk))
+	for _, field := range record.pk {
+		pk[field] = record.values[field]
+	}
+	s.cache.InvalidateRow(record.tableName, pk)
+}
+
+// createReturningPK runs an INSERT ... RETURNING style query and sets the
+// returned primary key value on record, for dialects where LastInsertId
+// isn't available.
+func (s *Session) createReturningPK(ctx context.Context, query string, args []interface{}, record *Record) error {
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var pkValue interface{}
+		if err := rows.Scan(&pkValue); err != nil {
+			return err
+		}
+		record.Set(record.pk[0], pkValue)
+	}
+	return rows.Err()
+}
+
+// SaveContext is the context-aware variant of Save.
+func (s *Session) SaveContext(ctx context.Context, record *Record) error {
+	if err := validateRecordForUpdateOrDelete(record); err != nil {
+		return err
+	}
+
+	args := []interface{}{}
+	fields := []string{}
+	for field, value := range record.values {
+		fields = append(fields, s.dialect.QuoteIdent(field)+" = "+s.dialect.Placeholder(len(args)+1))
+		args = append(args, value)
+	}
+
+	pkFields := []string{}
+	for _, field := range record.pk {
+		pkValue, ok := record.values[field]
+		if !ok {
+			return ErrPrimaryKeyInvalid
+		}
+		pkFields = append(pkFields, s.dialect.QuoteIdent(field)+" = "+s.dialect.Placeholder(len(args)+1))
+		args = append(args, pkValue)
+	}
+
+	query := "UPDATE " + record.tableName + " SET " + strings.Join(fields, ", ") + " WHERE " + strings.Join(pkFields, " AND ")
+	if s.dialect.SupportsLimitInUpdate() {
+		query += " LIMIT 1"
+	}
+
+	if _, err := s.execContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	s.invalidateRow(record)
+	return nil
+}
+
+// DeleteContext is the context-aware variant of Delete.
+func (s *Session) DeleteContext(ctx context.Context, record *Record) error {
+	if err := validateRecordForUpdateOrDelete(record); err != nil {
+		return err
+	}
+
+	args := []interface{}{}
+	pkFields := []string{}
+	for _, field := range record.pk {
+		pkValue, ok := record.values[field]
+		if !ok {
+			return ErrPrimaryKeyInvalid
+		}
+		pkFields = append(pkFields, s.dialect.QuoteIdent(field)+" = "+s.dialect.Placeholder(len(args)+1))
+		args = append(args, pkValue)
+	}
+
+	query := "DELETE FROM " + record.tableName + " WHERE " + strings.Join(pkFields, " AND ")
+	if s.dialect.SupportsLimitInUpdate() {
+		query += " LIMIT 1"
+	}
+
+	if _, err := s.execContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	s.invalidateRow(record)
+	return nil
+}
@@ -0,0 +1,45 @@
+package quicksql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectContextCanceled(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	session := NewSession(db)
+	_, err := session.SelectContext(ctx, "SELECT * FROM test_table")
+	assert.Error(t, err)
+}
+
+func TestCreateSaveDeleteContext(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	ctx := context.Background()
+	session := NewSession(db)
+
+	record := NewRecord(TableOption("test_table"), PrimaryKeyOption("id"), AutoIncrementOption())
+	record.Set("field_string", "field_string")
+	record.Set("field_integer", 666)
+	record.Set("field_binary", "binary")
+	record.Set("field_datetime", "2020-01-01")
+	record.Set("field_text", "text")
+	record.Set("field_decimal", 555.66)
+	assert.NoError(t, session.CreateContext(ctx, record))
+	assert.Equal(t, int64(2), record.MustInt64("id"))
+
+	assert.NoError(t, record.Set("field_string", "new value"))
+	assert.NoError(t, session.SaveContext(ctx, record))
+
+	assert.NoError(t, session.DeleteContext(ctx, record))
+}
@@ -0,0 +1,30 @@
+//go:build decimal
+
+package quicksql
+
+import "github.com/shopspring/decimal"
+
+// Decimal parses the column as a shopspring/decimal.Decimal, for callers
+// that need exact DECIMAL/NUMERIC arithmetic instead of the precision loss
+// of Float64. Only built when the "decimal" build tag is set, so quicksql
+// doesn't force the dependency on everyone.
+func (r *Record) Decimal(name string) (decimal.Decimal, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return decimal.Decimal{}, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return decimal.Decimal{}, ErrNullValue
+	}
+
+	return decimal.NewFromString(string(v))
+}
+
+func (r *Record) MustDecimal(name string) decimal.Decimal {
+	v, err := r.Decimal(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
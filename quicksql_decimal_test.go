@@ -0,0 +1,27 @@
+//go:build decimal
+
+package quicksql
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordDecimal(t *testing.T) {
+	record := NewRecord()
+	assert.NoError(t, record.Set("field_decimal", "555.66"))
+
+	value, err := record.Decimal("field_decimal")
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromFloat(555.66).Equal(value))
+}
+
+func TestRecordDecimalNull(t *testing.T) {
+	record := NewRecord()
+	assert.NoError(t, record.Set("field_decimal", nil))
+
+	_, err := record.Decimal("field_decimal")
+	assert.Equal(t, ErrNullValue, err)
+}
@@ -0,0 +1,74 @@
+package quicksql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect abstracts the SQL generation differences between backends so
+// Create, Save, and Delete can target more than MySQL.
+type Dialect interface {
+	// QuoteIdent quotes an identifier (table or column name) for safe use
+	// in a query.
+	QuoteIdent(ident string) string
+	// Placeholder returns the bind placeholder for the i-th argument
+	// (1-indexed) in a query.
+	Placeholder(i int) string
+	// SupportsLimitInUpdate reports whether the dialect allows a LIMIT
+	// clause on UPDATE/DELETE statements.
+	SupportsLimitInUpdate() bool
+	// InsertReturningPK rewrites an INSERT query so that executing it as
+	// a query (not an exec) returns the generated primary key columns.
+	// ok is false when the dialect has no such mechanism, in which case
+	// the caller falls back to sql.Result.LastInsertId.
+	InsertReturningPK(query string, pkCols []string) (string, bool)
+}
+
+// MySQLDialect is the default Dialect and matches quicksql's original
+// behavior: backtick-quoted identifiers, `?` placeholders, LIMIT 1 on
+// UPDATE/DELETE, and LastInsertId for auto-increment columns.
+type MySQLDialect struct{}
+
+func (MySQLDialect) QuoteIdent(ident string) string { return "`" + ident + "`" }
+
+func (MySQLDialect) Placeholder(int) string { return "?" }
+
+func (MySQLDialect) SupportsLimitInUpdate() bool { return true }
+
+func (MySQLDialect) InsertReturningPK(query string, pkCols []string) (string, bool) {
+	return query, false
+}
+
+// PostgresDialect targets PostgreSQL: double-quoted identifiers, `$1..$N`
+// placeholders, no LIMIT on UPDATE/DELETE, and RETURNING for auto-increment
+// columns instead of LastInsertId.
+type PostgresDialect struct{}
+
+func (PostgresDialect) QuoteIdent(ident string) string { return `"` + ident + `"` }
+
+func (PostgresDialect) Placeholder(i int) string { return "$" + strconv.Itoa(i) }
+
+func (PostgresDialect) SupportsLimitInUpdate() bool { return false }
+
+func (d PostgresDialect) InsertReturningPK(query string, pkCols []string) (string, bool) {
+	quoted := make([]string, len(pkCols))
+	for i, col := range pkCols {
+		quoted[i] = d.QuoteIdent(col)
+	}
+	return query + " RETURNING " + strings.Join(quoted, ", "), true
+}
+
+// SQLiteDialect targets SQLite: double-quoted identifiers, `?`
+// placeholders, no LIMIT on UPDATE/DELETE by default, and LastInsertId for
+// auto-increment columns.
+type SQLiteDialect struct{}
+
+func (SQLiteDialect) QuoteIdent(ident string) string { return `"` + ident + `"` }
+
+func (SQLiteDialect) Placeholder(int) string { return "?" }
+
+func (SQLiteDialect) SupportsLimitInUpdate() bool { return false }
+
+func (SQLiteDialect) InsertReturningPK(query string, pkCols []string) (string, bool) {
+	return query, false
+}
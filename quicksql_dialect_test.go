@@ -0,0 +1,137 @@
+package quicksql
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSqlInterface is a SqlInterface that records the last query/args it was
+// called with instead of talking to a real database, so the dialect-routing
+// tests below can assert on the generated SQL without a live Postgres/SQLite
+// connection.
+type fakeSqlInterface struct {
+	lastQuery string
+	lastArgs  []interface{}
+	queryErr  error
+	execErr   error
+	result    sql.Result
+}
+
+func (f *fakeSqlInterface) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	f.lastQuery = query
+	f.lastArgs = args
+	return nil, f.queryErr
+}
+
+func (f *fakeSqlInterface) Exec(query string, args ...interface{}) (sql.Result, error) {
+	f.lastQuery = query
+	f.lastArgs = args
+	if f.execErr != nil {
+		return nil, f.execErr
+	}
+	return f.result, nil
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+func TestMySQLDialect(t *testing.T) {
+	d := MySQLDialect{}
+	assert.Equal(t, "`id`", d.QuoteIdent("id"))
+	assert.Equal(t, "?", d.Placeholder(1))
+	assert.True(t, d.SupportsLimitInUpdate())
+
+	query, ok := d.InsertReturningPK("INSERT INTO t (a) VALUES(?)", []string{"id"})
+	assert.False(t, ok)
+	assert.Equal(t, "INSERT INTO t (a) VALUES(?)", query)
+}
+
+func TestPostgresDialect(t *testing.T) {
+	d := PostgresDialect{}
+	assert.Equal(t, `"id"`, d.QuoteIdent("id"))
+	assert.Equal(t, "$2", d.Placeholder(2))
+	assert.False(t, d.SupportsLimitInUpdate())
+
+	query, ok := d.InsertReturningPK("INSERT INTO t (a) VALUES($1)", []string{"id"})
+	assert.True(t, ok)
+	assert.Equal(t, `INSERT INTO t (a) VALUES($1) RETURNING "id"`, query)
+}
+
+func TestSQLiteDialect(t *testing.T) {
+	d := SQLiteDialect{}
+	assert.Equal(t, `"id"`, d.QuoteIdent("id"))
+	assert.Equal(t, "?", d.Placeholder(1))
+	assert.False(t, d.SupportsLimitInUpdate())
+
+	query, ok := d.InsertReturningPK("INSERT INTO t (a) VALUES(?)", []string{"id"})
+	assert.False(t, ok)
+	assert.Equal(t, "INSERT INTO t (a) VALUES(?)", query)
+}
+
+// TestDeleteContextDefaultsToMySQLDialect pins down the default, for
+// contrast with the Postgres-routed tests below: `?` placeholders,
+// backtick-quoted identifiers, and a LIMIT 1 clause.
+func TestDeleteContextDefaultsToMySQLDialect(t *testing.T) {
+	db := &fakeSqlInterface{result: fakeResult{}}
+	session := NewSession(db)
+
+	record := NewRecord(TableOption("widgets"), PrimaryKeyOption("id"))
+	assert.NoError(t, record.Set("id", 1))
+
+	assert.NoError(t, session.Delete(record))
+	assert.Equal(t, "DELETE FROM widgets WHERE `id` = ? LIMIT 1", db.lastQuery)
+}
+
+// TestCreateContextRoutesThroughPostgresDialect verifies that
+// DialectOption(PostgresDialect{}) makes CreateContext emit $N placeholders,
+// double-quoted identifiers, and an INSERT ... RETURNING query instead of
+// relying on LastInsertId.
+func TestCreateContextRoutesThroughPostgresDialect(t *testing.T) {
+	db := &fakeSqlInterface{queryErr: errors.New("fake: no connection")}
+	session := NewSession(db, DialectOption(PostgresDialect{}))
+
+	record := NewRecord(TableOption("widgets"), PrimaryKeyOption("id"), AutoIncrementOption())
+	assert.NoError(t, record.Set("name", "gadget"))
+
+	err := session.Create(record)
+	assert.Error(t, err)
+	assert.Equal(t, `INSERT INTO widgets ("name") VALUES($1) RETURNING "id"`, db.lastQuery)
+}
+
+// TestSaveContextRoutesThroughPostgresDialect verifies that SaveContext
+// generates $N placeholders and double-quoted identifiers, and omits the
+// LIMIT clause MySQL's UPDATE ... LIMIT 1 relies on, since Postgres doesn't
+// support it.
+func TestSaveContextRoutesThroughPostgresDialect(t *testing.T) {
+	db := &fakeSqlInterface{result: fakeResult{}}
+	session := NewSession(db, DialectOption(PostgresDialect{}))
+
+	record := NewRecord(TableOption("widgets"), PrimaryKeyOption("id"))
+	assert.NoError(t, record.Set("id", 1))
+	assert.NoError(t, record.Set("name", "gadget"))
+
+	assert.NoError(t, session.Save(record))
+	assert.Contains(t, db.lastQuery, `UPDATE widgets SET`)
+	assert.Contains(t, db.lastQuery, `"name" = $`)
+	assert.Contains(t, db.lastQuery, `"id" = $`)
+	assert.Contains(t, db.lastQuery, "WHERE")
+	assert.NotContains(t, db.lastQuery, "LIMIT")
+}
+
+// TestDeleteContextRoutesThroughPostgresDialect is the DeleteContext
+// equivalent of TestSaveContextRoutesThroughPostgresDialect.
+func TestDeleteContextRoutesThroughPostgresDialect(t *testing.T) {
+	db := &fakeSqlInterface{result: fakeResult{}}
+	session := NewSession(db, DialectOption(PostgresDialect{}))
+
+	record := NewRecord(TableOption("widgets"), PrimaryKeyOption("id"))
+	assert.NoError(t, record.Set("id", 1))
+
+	assert.NoError(t, session.Delete(record))
+	assert.Equal(t, `DELETE FROM widgets WHERE "id" = $1`, db.lastQuery)
+}
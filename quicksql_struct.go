@@ -0,0 +1,229 @@
+package quicksql
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// SelectInto runs query and scans the resulting rows into dest, which must
+// be a pointer to a slice of structs (or a slice of struct pointers). Struct
+// fields are matched against column names via their `db` struct tag;
+// unexported or untagged fields are left untouched and columns without a
+// matching field are ignored. It is built on top of Select, so the same
+// SessionOption values (ArgsOption, TableOption, ...) apply here.
+func (s *Session) SelectInto(dest interface{}, query string, options ...SessionOption) error {
+	records, err := s.Select(query, options...)
+	if err != nil {
+		return err
+	}
+
+	sliceVal, err := sliceDestValue(dest)
+	if err != nil {
+		return err
+	}
+
+	elemType := sliceVal.Type().Elem()
+	for _, record := range records {
+		structPtr, elem, err := newStructElem(elemType)
+		if err != nil {
+			return err
+		}
+
+		if err := populateStruct(structPtr.Elem(), record); err != nil {
+			return err
+		}
+
+		sliceVal.Set(reflect.Append(sliceVal, elem))
+	}
+
+	return nil
+}
+
+// SelectOneInto runs query and scans the first row of the result set into
+// dest, which must be a pointer to a struct. It returns sql.ErrNoRows if
+// the query didn't match any row, mirroring database/sql's QueryRow.
+func (s *Session) SelectOneInto(dest interface{}, query string, options ...SessionOption) error {
+	records, err := s.Select(query, options...)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 {
+		return sql.ErrNoRows
+	}
+
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() || destVal.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDestination
+	}
+
+	return populateStruct(destVal.Elem(), records[0])
+}
+
+func sliceDestValue(dest interface{}) (reflect.Value, error) {
+	destVal := reflect.ValueOf(dest)
+	if destVal.Kind() != reflect.Ptr || destVal.IsNil() {
+		return reflect.Value{}, ErrInvalidDestination
+	}
+
+	sliceVal := destVal.Elem()
+	if sliceVal.Kind() != reflect.Slice {
+		return reflect.Value{}, ErrInvalidDestination
+	}
+
+	return sliceVal, nil
+}
+
+// newStructElem allocates a new value of elemType (a struct or a pointer to
+// one) and returns both the addressable struct pointer used to populate the
+// fields and the value to append to the destination slice.
+func newStructElem(elemType reflect.Type) (reflect.Value, reflect.Value, error) {
+	if elemType.Kind() == reflect.Ptr {
+		if elemType.Elem().Kind() != reflect.Struct {
+			return reflect.Value{}, reflect.Value{}, ErrInvalidDestination
+		}
+		ptr := reflect.New(elemType.Elem())
+		return ptr, ptr, nil
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, ErrInvalidDestination
+	}
+
+	ptr := reflect.New(elemType)
+	return ptr, ptr.Elem(), nil
+}
+
+func populateStruct(structVal reflect.Value, record *Record) error {
+	structType := structVal.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			// unexported field, can't be set via reflection
+			continue
+		}
+
+		col, ok := field.Tag.Lookup("db")
+		if !ok || col == "-" {
+			continue
+		}
+
+		raw, ok := record.values[col]
+		if !ok {
+			// unknown column, leave the field as-is
+			continue
+		}
+
+		if err := setFieldValue(structVal.Field(i), raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setFieldValue assigns the raw bytes read from the database to fv,
+// coercing the value according to fv's type. raw is nil when the column
+// value was NULL.
+func setFieldValue(fv reflect.Value, raw []byte) error {
+	switch v := fv.Addr().Interface().(type) {
+	case *sql.NullString:
+		v.Valid = raw != nil
+		if v.Valid {
+			v.String = string(raw)
+		}
+		return nil
+	case *sql.NullInt64:
+		v.Valid = raw != nil
+		if v.Valid {
+			n, err := strconv.ParseInt(string(raw), 10, 64)
+			if err != nil {
+				return err
+			}
+			v.Int64 = n
+		}
+		return nil
+	case *sql.NullFloat64:
+		v.Valid = raw != nil
+		if v.Valid {
+			n, err := strconv.ParseFloat(string(raw), 64)
+			if err != nil {
+				return err
+			}
+			v.Float64 = n
+		}
+		return nil
+	case *sql.NullBool:
+		v.Valid = raw != nil
+		if v.Valid {
+			b, err := strconv.ParseBool(string(raw))
+			if err != nil {
+				return err
+			}
+			v.Bool = b
+		}
+		return nil
+	case *sql.NullTime:
+		v.Valid = raw != nil
+		if v.Valid {
+			t, err := time.Parse(timeLayout, string(raw))
+			if err != nil {
+				return err
+			}
+			v.Time = t
+		}
+		return nil
+	case *time.Time:
+		if raw == nil {
+			return nil
+		}
+		t, err := time.Parse(timeLayout, string(raw))
+		if err != nil {
+			return err
+		}
+		*v = t
+		return nil
+	}
+
+	if raw == nil {
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(string(raw))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(string(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(string(raw))
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			fv.SetBytes(raw)
+		}
+	}
+
+	return nil
+}
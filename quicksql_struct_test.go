@@ -0,0 +1,42 @@
+package quicksql
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTableRow struct {
+	ID           int64  `db:"id"`
+	FieldString  string `db:"field_string"`
+	FieldInteger int    `db:"field_integer"`
+}
+
+func TestSelectInto(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	var rows []testTableRow
+	assert.NoError(t, session.SelectInto(&rows, "SELECT id, field_string, field_integer FROM test_table"))
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, "field_string", rows[0].FieldString)
+	assert.Equal(t, 666, rows[0].FieldInteger)
+}
+
+func TestSelectOneInto(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	var row testTableRow
+	assert.NoError(t, session.SelectOneInto(&row, "SELECT id, field_string, field_integer FROM test_table LIMIT 1"))
+	assert.Equal(t, "field_string", row.FieldString)
+
+	var missing testTableRow
+	err := session.SelectOneInto(&missing, "SELECT id, field_string, field_integer FROM test_table WHERE id = ?", ArgsOption(-1))
+	assert.Equal(t, sql.ErrNoRows, err)
+}
@@ -0,0 +1,42 @@
+package quicksql
+
+import "time"
+
+// Time parses the column as a time.Time using timeLayout, interpreting it
+// as UTC. Use TimeInLocation when the column's values were written in a
+// specific timezone.
+func (r *Record) Time(name string) (time.Time, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return time.Time{}, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return time.Time{}, ErrNullValue
+	}
+
+	return time.Parse(timeLayout, string(v))
+}
+
+func (r *Record) MustTime(name string) time.Time {
+	v, err := r.Time(name)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TimeInLocation is the Time equivalent for columns whose values should be
+// interpreted in a specific timezone rather than UTC.
+func (r *Record) TimeInLocation(name string, location *time.Location) (time.Time, error) {
+	v, ok := r.values[name]
+	if !ok {
+		return time.Time{}, ErrInvalidColumn
+	}
+
+	if v == nil {
+		return time.Time{}, ErrNullValue
+	}
+
+	return time.ParseInLocation(timeLayout, string(v), location)
+}
@@ -0,0 +1,186 @@
+package quicksql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// txBeginner is satisfied by drivers (such as *sql.DB) that can start a
+// transaction. *sql.Tx itself doesn't implement it, so a TxSession can't be
+// nested into another transaction.
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxSession is a Session bound to a single *sql.Tx. It exposes the same
+// Select/Create/Save/Delete surface (and their Context variants) as
+// Session, but every statement participates in the transaction until it is
+// committed or rolled back.
+//
+// The embedded Session's cache is intentionally left nil: reads and writes
+// made through a TxSession never touch the parent Session's cache, since
+// other callers using CacheOption on the parent could otherwise observe
+// rows from a transaction that hasn't committed (or never will). Any
+// invalidations a committed transaction would have triggered are deferred
+// and flushed against the parent's cache in Commit, and discarded on
+// Rollback.
+type TxSession struct {
+	Session
+	tx            *sql.Tx
+	parentCache   Cache
+	invalidations []func()
+}
+
+// Begin starts a transaction and returns a TxSession bound to it. db must
+// have been constructed from something that supports BeginTx (such as
+// *sql.DB); ErrTxNotSupported is returned otherwise.
+func (s *Session) Begin(ctx context.Context, opts *sql.TxOptions) (*TxSession, error) {
+	beginner, ok := s.db.(txBeginner)
+	if !ok {
+		return nil, ErrTxNotSupported
+	}
+
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxSession{
+		Session:     Session{db: tx, dialect: s.dialect},
+		tx:          tx,
+		parentCache: s.cache,
+	}, nil
+}
+
+// Commit commits the underlying transaction, then applies to the parent
+// Session's cache whatever invalidations Create/Save/Delete accumulated
+// while the transaction was open.
+func (tx *TxSession) Commit() error {
+	if err := tx.tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, invalidate := range tx.invalidations {
+		invalidate()
+	}
+	tx.invalidations = nil
+	return nil
+}
+
+// Rollback aborts the underlying transaction and discards any pending
+// cache invalidations, since nothing the transaction did actually happened.
+func (tx *TxSession) Rollback() error {
+	err := tx.tx.Rollback()
+	tx.invalidations = nil
+	return err
+}
+
+// Create runs Session.Create inside the transaction, deferring the
+// resulting cache invalidation until Commit.
+func (tx *TxSession) Create(record *Record) error {
+	return tx.CreateContext(context.Background(), record)
+}
+
+// CreateContext is the context-aware variant of Create.
+func (tx *TxSession) CreateContext(ctx context.Context, record *Record) error {
+	if err := tx.Session.CreateContext(ctx, record); err != nil {
+		return err
+	}
+	tx.deferTableInvalidation(record.tableName)
+	return nil
+}
+
+// Save runs Session.Save inside the transaction, deferring the resulting
+// cache invalidation until Commit.
+func (tx *TxSession) Save(record *Record) error {
+	return tx.SaveContext(context.Background(), record)
+}
+
+// SaveContext is the context-aware variant of Save.
+func (tx *TxSession) SaveContext(ctx context.Context, record *Record) error {
+	if err := tx.Session.SaveContext(ctx, record); err != nil {
+		return err
+	}
+	tx.deferRowInvalidation(record)
+	return nil
+}
+
+// Delete runs Session.Delete inside the transaction, deferring the
+// resulting cache invalidation until Commit.
+func (tx *TxSession) Delete(record *Record) error {
+	return tx.DeleteContext(context.Background(), record)
+}
+
+// DeleteContext is the context-aware variant of Delete.
+func (tx *TxSession) DeleteContext(ctx context.Context, record *Record) error {
+	if err := tx.Session.DeleteContext(ctx, record); err != nil {
+		return err
+	}
+	tx.deferRowInvalidation(record)
+	return nil
+}
+
+// CreateMany runs Session.CreateMany inside the transaction, deferring the
+// resulting cache invalidations until Commit.
+func (tx *TxSession) CreateMany(records []*Record) error {
+	if err := tx.Session.CreateMany(records); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, record := range records {
+		if !seen[record.tableName] {
+			seen[record.tableName] = true
+			tx.deferTableInvalidation(record.tableName)
+		}
+	}
+	return nil
+}
+
+func (tx *TxSession) deferTableInvalidation(table string) {
+	if tx.parentCache == nil {
+		return
+	}
+	tx.invalidations = append(tx.invalidations, func() {
+		tx.parentCache.InvalidateTable(table)
+	})
+}
+
+func (tx *TxSession) deferRowInvalidation(record *Record) {
+	if tx.parentCache == nil {
+		return
+	}
+
+	table := record.tableName
+	pk := make(map[string]interface{}, len(record.pk))
+	for _, field := range record.pk {
+		pk[field] = record.values[field]
+	}
+
+	tx.invalidations = append(tx.invalidations, func() {
+		tx.parentCache.InvalidateRow(table, pk)
+	})
+}
+
+// InTx runs fn inside a new transaction, committing if fn returns nil and
+// rolling back otherwise. A panic inside fn also rolls back the
+// transaction before being re-panicked.
+func (s *Session) InTx(ctx context.Context, fn func(*TxSession) error) (err error) {
+	txSession, err := s.Begin(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			txSession.Rollback()
+			panic(p)
+		} else if err != nil {
+			txSession.Rollback()
+		} else {
+			err = txSession.Commit()
+		}
+	}()
+
+	err = fn(txSession)
+	return err
+}
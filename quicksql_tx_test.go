@@ -0,0 +1,50 @@
+package quicksql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInTxCommits(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	err := session.InTx(context.Background(), func(tx *TxSession) error {
+		record := NewRecord(TableOption("test_table"), PrimaryKeyOption("id"), AutoIncrementOption())
+		record.Set("field_string", "field_string")
+		record.Set("field_integer", 666)
+		record.Set("field_binary", "binary")
+		record.Set("field_datetime", "2020-01-01")
+		record.Set("field_text", "text")
+		record.Set("field_decimal", 555.66)
+		return tx.Create(record)
+	})
+	assert.NoError(t, err)
+
+	rows, err := session.Select("SELECT COUNT(*) AS c FROM test_table")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rows[0].MustInt64("c"))
+}
+
+func TestInTxRollsBackOnError(t *testing.T) {
+	db := openMySQL(t)
+	defer db.Close()
+	assert.NoError(t, createTables(db))
+
+	session := NewSession(db)
+	wantErr := ErrTableNotSet
+	err := session.InTx(context.Background(), func(tx *TxSession) error {
+		record := NewRecord(PrimaryKeyOption("id"), AutoIncrementOption())
+		record.Set("field_string", "field_string")
+		return tx.Create(record)
+	})
+	assert.Equal(t, wantErr, err)
+
+	rows, err := session.Select("SELECT COUNT(*) AS c FROM test_table")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows[0].MustInt64("c"))
+}
@@ -0,0 +1,71 @@
+package quicksql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordFloat64(t *testing.T) {
+	record := NewRecord()
+	assert.NoError(t, record.Set("field_decimal", "555.66"))
+
+	value, err := record.Float64("field_decimal")
+	assert.NoError(t, err)
+	assert.Equal(t, 555.66, value)
+}
+
+func TestRecordBool(t *testing.T) {
+	record := NewRecord()
+	assert.NoError(t, record.Set("field_bool", "1"))
+
+	value, err := record.Bool("field_bool")
+	assert.NoError(t, err)
+	assert.True(t, value)
+}
+
+func TestRecordBytes(t *testing.T) {
+	record := NewRecord()
+	raw := []byte{0x01, 0x02, 0x03}
+	assert.NoError(t, record.Set("field_binary", raw))
+
+	value, err := record.Bytes("field_binary")
+	assert.NoError(t, err)
+	assert.Equal(t, raw, value)
+}
+
+func TestRecordNullVariants(t *testing.T) {
+	record := NewRecord()
+	assert.NoError(t, record.Set("field_string_nullable", nil))
+	assert.NoError(t, record.Set("field_integer_nullable", nil))
+	assert.NoError(t, record.Set("field_datetime_nullable", nil))
+
+	_, isNull, err := record.NullString("field_string_nullable")
+	assert.NoError(t, err)
+	assert.True(t, isNull)
+
+	_, isNull, err = record.NullInt64("field_integer_nullable")
+	assert.NoError(t, err)
+	assert.True(t, isNull)
+
+	_, isNull, err = record.NullTime("field_datetime_nullable")
+	assert.NoError(t, err)
+	assert.True(t, isNull)
+
+	assert.NoError(t, record.Set("field_string_nullable", "hello"))
+	value, isNull, err := record.NullString("field_string_nullable")
+	assert.NoError(t, err)
+	assert.False(t, isNull)
+	assert.Equal(t, "hello", value)
+}
+
+func TestRecordSetTime(t *testing.T) {
+	record := NewRecord()
+	ts := time.Date(2020, time.March, 4, 15, 30, 44, 0, time.UTC)
+	assert.NoError(t, record.Set("field_datetime", ts))
+
+	value, err := record.String("field_datetime")
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-03-04 15:30:44", value)
+}